@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apex/log"
+	"github.com/goreleaser/goreleaser/pkg/build/cache"
+	"github.com/spf13/cobra"
+)
+
+type cacheCmd struct {
+	cmd *cobra.Command
+}
+
+// newCacheCmd builds the `goreleaser cache` command group.
+func newCacheCmd() *cacheCmd {
+	root := &cacheCmd{}
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the local build cache",
+	}
+	cmd.AddCommand(newCachePruneCmd().cmd)
+	root.cmd = cmd
+	return root
+}
+
+type cachePruneCmd struct {
+	cmd  *cobra.Command
+	opts cachePruneOpts
+}
+
+type cachePruneOpts struct {
+	maxAge  time.Duration
+	maxSize int64
+}
+
+func newCachePruneCmd() *cachePruneCmd {
+	root := &cachePruneCmd{}
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove old and/or excess entries from the build cache",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			freed, err := cache.Prune(root.opts.maxAge, root.opts.maxSize)
+			if err != nil {
+				return fmt.Errorf("failed to prune build cache: %w", err)
+			}
+			log.WithField("freed_bytes", freed).Info("pruned build cache")
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVar(&root.opts.maxAge, "max-age", 0, "remove cache entries older than this (e.g. 168h); 0 disables the age check")
+	cmd.Flags().Int64Var(&root.opts.maxSize, "max-size", 0, "remove the oldest cache entries until the cache is at most this many bytes; 0 disables the size check")
+
+	root.cmd = cmd
+	return root
+}
@@ -0,0 +1,53 @@
+// Package cmd implements goreleaser's CLI commands.
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var noBuildCache bool
+
+// NewRootCmd builds the root `goreleaser` command and wires up its
+// subcommands.
+func NewRootCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "goreleaser",
+		Short:         "Deliver Go binaries as fast and easily as possible",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	cmd.PersistentFlags().BoolVar(&noBuildCache, "no-build-cache", false, "disable the on-disk build cache, forcing every target to be recompiled")
+
+	cmd.AddCommand(newVerifyReproducibleCmd().cmd)
+	cmd.AddCommand(newCacheCmd().cmd)
+
+	return cmd
+}
+
+// Execute runs the root command.
+func Execute() error {
+	return NewRootCmd().Execute()
+}
+
+// loadConfig reads and parses the goreleaser config file at path.
+func loadConfig(path string) (config.Project, error) {
+	var proj config.Project
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return proj, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, &proj); err != nil {
+		return proj, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	proj.ConfigFile = path
+	return proj, nil
+}
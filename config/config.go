@@ -0,0 +1,23 @@
+// Package config contains the model and configuration options for
+// goreleaser.
+package config
+
+// Git configures the git pipe.
+type Git struct {
+	// TagPrefix restricts `git describe` to tags matching "<prefix>v*"
+	// and strips the prefix back off before semver validation, for
+	// monorepos that tag each subpackage independently (e.g. "subpkg/").
+	TagPrefix string `yaml:"tag_prefix,omitempty"`
+
+	// RequireSignedTag makes the git pipe run `git verify-tag` on the
+	// resolved tag and fail if it's unsigned or signed by a key outside
+	// AllowedSignersFile.
+	RequireSignedTag   bool   `yaml:"require_signed_tag,omitempty"`
+	AllowedSignersFile string `yaml:"allowed_signers_file,omitempty"`
+}
+
+// Project includes all project configuration.
+type Project struct {
+	ProjectName string `yaml:"project_name,omitempty"`
+	Git         Git    `yaml:"git,omitempty"`
+}
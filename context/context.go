@@ -0,0 +1,27 @@
+// Package context provides the context used throughout goreleaser to
+// pass around its configuration and the state collected by each pipe.
+package context
+
+import (
+	"context"
+
+	"github.com/goreleaser/goreleaser/config"
+)
+
+// GitInfo includes tags and diffs used in some point.
+type GitInfo struct {
+	CurrentTag  string
+	PreviousTag string
+	Commit      string
+	Diff        string
+}
+
+// Context carries the config and state along the pipe chain.
+type Context struct {
+	context.Context
+	Config config.Project
+	Git    GitInfo
+	// Strict makes pipes fail instead of working around recoverable
+	// problems, such as a shallow clone missing tag history.
+	Strict bool
+}
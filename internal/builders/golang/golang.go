@@ -0,0 +1,154 @@
+// Package golang implements the build.Builder interface for Go, using go
+// build as the underlying builder.
+package golang
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/apex/log"
+	"github.com/goreleaser/goreleaser/internal/logext"
+	"github.com/goreleaser/goreleaser/internal/tmpl"
+	builders "github.com/goreleaser/goreleaser/pkg/build"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+)
+
+const defaultGoBinary = "go"
+
+// defaultTargets is a small set of common darwin, linux and windows
+// os_arch pairs taken from `go tool dist list`; other platforms are only
+// built when listed in `targets`.
+var defaultTargets = []string{
+	"darwin_amd64", "darwin_arm64",
+	"linux_386", "linux_amd64", "linux_arm64",
+	"windows_386", "windows_amd64",
+}
+
+func init() {
+	builders.Register("go", Builder{})
+}
+
+// Builder is the Go implementation of build.Builder.
+type Builder struct{}
+
+// WithDefaults sets the default values for a Go build.
+func (Builder) WithDefaults(build config.Build) (config.Build, error) {
+	if build.GoBinary == "" {
+		build.GoBinary = defaultGoBinary
+	}
+	if build.Main == "" {
+		build.Main = "."
+	}
+	if len(build.Ldflags) == 0 {
+		build.Ldflags = config.FlagArray{"-s -w -X main.version={{.Version}} -X main.commit={{.Commit}}"}
+	}
+	return build, nil
+}
+
+// DefaultTargets returns the os_arch pairs to build for when a build
+// doesn't set `targets` explicitly.
+func (Builder) DefaultTargets(ctx *context.Context, build config.Build) ([]string, error) {
+	return defaultTargets, nil
+}
+
+// Parse breaks a "goos_goarch[_goarm|_gomips]" target down into its parts.
+func (Builder) Parse(target string, flags config.FlagArray) (builders.Target, error) {
+	parts := strings.Split(target, "_")
+	if len(parts) < 2 {
+		return builders.Target{}, fmt.Errorf("invalid build target: %s", target)
+	}
+
+	t := builders.Target{
+		Os:    parts[0],
+		Arch:  parts[1],
+		Extra: map[string]string{},
+	}
+	if len(parts) > 2 {
+		if t.Arch == "arm" {
+			t.Extra["goarm"] = parts[2]
+		} else {
+			t.Extra["gomips"] = parts[2]
+		}
+	}
+	t.Ext = extFor(target, flags)
+	return t, nil
+}
+
+func extFor(target string, flags config.FlagArray) string {
+	if strings.Contains(target, "windows") {
+		for _, s := range flags {
+			if s == "-buildmode=c-shared" {
+				return ".dll"
+			}
+			if s == "-buildmode=c-archive" {
+				return ".lib"
+			}
+		}
+		return ".exe"
+	}
+	if target == "js_wasm" {
+		return ".wasm"
+	}
+	return ""
+}
+
+// Command returns the `go build` invocation for the given target, so
+// callers (e.g. the build pipe's container support) can run it themselves
+// instead of going through Build.
+func (b Builder) Command(build config.Build, options builders.Options) ([]string, []string, error) {
+	target, err := b.Parse(options.Target, build.Flags)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	env := append([]string{}, build.Env...)
+	env = append(env,
+		"GOOS="+target.Os,
+		"GOARCH="+target.Arch,
+	)
+	if goarm, ok := target.Extra["goarm"]; ok {
+		env = append(env, "GOARM="+goarm)
+	}
+	if gomips, ok := target.Extra["gomips"]; ok {
+		env = append(env, "GOMIPS="+gomips)
+	}
+
+	ldflags := strings.Join(build.Ldflags, " ")
+
+	args := []string{build.GoBinary, "build", "-trimpath"}
+	args = append(args, build.Flags...)
+	args = append(args, "-ldflags", ldflags, "-o", options.Path, build.Main)
+
+	return args, env, nil
+}
+
+// Build builds a single Go binary.
+func (b Builder) Build(ctx *context.Context, build config.Build, options builders.Options) error {
+	tpl := tmpl.New(ctx).WithBuildOptions(options)
+	ldflags, err := tpl.Apply(strings.Join(build.Ldflags, " "))
+	if err != nil {
+		return fmt.Errorf("failed to apply ldflags template: %w", err)
+	}
+	build.Ldflags = config.FlagArray{ldflags}
+
+	args, cmdEnv, err := b.Command(build, options)
+	if err != nil {
+		return err
+	}
+
+	env := append(ctx.Env.Strings(), cmdEnv...)
+
+	/* #nosec */
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Env = env
+	cmd.Dir = build.Dir
+	entry := log.WithField("cmd", args).WithField("env", env)
+	cmd.Stdout = logext.NewWriter(entry)
+	cmd.Stderr = logext.NewErrWriter(entry)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to build for %s: %w", options.Target, err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+// Package raw implements the build.Builder interface for builds that
+// don't compile anything: it just copies an already-built binary into
+// place, for projects that produce their binaries with a toolchain
+// goreleaser has no builder for.
+package raw
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/goreleaser/goreleaser/internal/tmpl"
+	builders "github.com/goreleaser/goreleaser/pkg/build"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+)
+
+func init() {
+	builders.Register("raw", Builder{})
+}
+
+// Builder is the "raw"/prebuilt implementation of build.Builder.
+//
+// `build.main` is used as the path of the pre-built binary to consume,
+// templated per target so a single build config can point at several
+// already-compiled artifacts.
+type Builder struct{}
+
+// WithDefaults sets the default values for a raw build.
+func (Builder) WithDefaults(build config.Build) (config.Build, error) {
+	if build.Main == "" {
+		return build, fmt.Errorf("build.main is required for raw builds: path to the pre-built binary")
+	}
+	return build, nil
+}
+
+// DefaultTargets returns the single pseudo-target raw builds run with,
+// since there's no matrix to expand: the binary is already built for
+// whatever target it targets.
+func (Builder) DefaultTargets(ctx *context.Context, build config.Build) ([]string, error) {
+	return []string{"raw"}, nil
+}
+
+// Parse is a no-op for raw builds: there's no target syntax to decode
+// since the binary was built outside of goreleaser.
+func (Builder) Parse(target string, flags config.FlagArray) (builders.Target, error) {
+	return builders.Target{}, nil
+}
+
+// Build copies the pre-built binary at build.Main into options.Path.
+func (Builder) Build(ctx *context.Context, build config.Build, options builders.Options) error {
+	main, err := tmpl.New(ctx).WithBuildOptions(options).Apply(build.Main)
+	if err != nil {
+		return fmt.Errorf("failed to apply main template: %w", err)
+	}
+
+	src, err := os.Open(main)
+	if err != nil {
+		return fmt.Errorf("failed to open pre-built binary: %w", err)
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(options.Path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o755)
+	if err != nil {
+		return fmt.Errorf("failed to create output binary: %w", err)
+	}
+	defer dst.Close()
+
+	if _, err := io.Copy(dst, src); err != nil {
+		return fmt.Errorf("failed to copy pre-built binary: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+// Package rust implements the build.Builder interface for Rust, using
+// cargo as the underlying builder.
+package rust
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/apex/log"
+	"github.com/goreleaser/goreleaser/internal/logext"
+	builders "github.com/goreleaser/goreleaser/pkg/build"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+)
+
+// defaultTargets are the Rust target triples covered by the standard
+// `rustup target add` set, one per supported OS/arch pair.
+var defaultTargets = []string{
+	"x86_64-unknown-linux-gnu",
+	"aarch64-unknown-linux-gnu",
+	"x86_64-apple-darwin",
+	"aarch64-apple-darwin",
+	"x86_64-pc-windows-gnu",
+}
+
+func init() {
+	builders.Register("rust", Builder{})
+}
+
+// Builder is the Rust implementation of build.Builder.
+type Builder struct{}
+
+// WithDefaults sets the default values for a Rust build.
+func (Builder) WithDefaults(build config.Build) (config.Build, error) {
+	if build.Main == "" {
+		build.Main = "."
+	}
+	return build, nil
+}
+
+// DefaultTargets returns the target triples to build for when a build
+// doesn't set `targets` explicitly.
+func (Builder) DefaultTargets(ctx *context.Context, build config.Build) ([]string, error) {
+	return defaultTargets, nil
+}
+
+// Parse breaks a target triple such as "x86_64-unknown-linux-gnu" down
+// into its OS/arch, keeping the full triple (and the abi component, when
+// present) available as extras since cargo needs the full triple back when
+// invoking the build.
+func (Builder) Parse(target string, flags config.FlagArray) (builders.Target, error) {
+	parts := strings.SplitN(target, "-", 4)
+	if len(parts) < 3 {
+		return builders.Target{}, fmt.Errorf("invalid rust target triple: %s", target)
+	}
+
+	t := builders.Target{
+		Arch: parts[0],
+		Os:   parts[2],
+		Extra: map[string]string{
+			"triple": target,
+		},
+	}
+	if len(parts) == 4 {
+		t.Extra["abi"] = parts[3]
+	}
+	if t.Os == "windows" {
+		t.Ext = ".exe"
+	}
+	return t, nil
+}
+
+// Build builds a single Rust binary via `cargo build --release --target`.
+func (b Builder) Build(ctx *context.Context, build config.Build, options builders.Options) error {
+	target, err := b.Parse(options.Target, build.Flags)
+	if err != nil {
+		return err
+	}
+
+	env := append(ctx.Env.Strings(), build.Env...)
+
+	args := []string{"build", "--release", "--target", target.Extra["triple"]}
+	args = append(args, build.Flags...)
+
+	/* #nosec */
+	cmd := exec.CommandContext(ctx, "cargo", args...)
+	cmd.Env = env
+	cmd.Dir = build.Dir
+	entry := log.WithField("cmd", args).WithField("env", env)
+	cmd.Stdout = logext.NewWriter(entry)
+	cmd.Stderr = logext.NewErrWriter(entry)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to build for %s: %w", options.Target, err)
+	}
+
+	name := build.Binary + target.Ext
+	builtPath := fmt.Sprintf("%s/target/%s/release/%s", build.Dir, target.Extra["triple"], name)
+	return copyBinary(ctx, builtPath, options.Path)
+}
+
+func copyBinary(ctx *context.Context, src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open built binary: %w", err)
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o755)
+	if err != nil {
+		return fmt.Errorf("failed to create output binary: %w", err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, in); err != nil {
+		return fmt.Errorf("failed to copy built binary: %w", err)
+	}
+	return nil
+}
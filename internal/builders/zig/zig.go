@@ -0,0 +1,92 @@
+// Package zig implements the build.Builder interface for Zig, using
+// `zig build-exe`/`zig cc`-style cross compilation as the underlying
+// builder.
+package zig
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/apex/log"
+	"github.com/goreleaser/goreleaser/internal/logext"
+	builders "github.com/goreleaser/goreleaser/pkg/build"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+)
+
+// defaultTargets mirrors `zig targets`' most common arch-os-abi triples.
+var defaultTargets = []string{
+	"x86_64-linux-gnu",
+	"aarch64-linux-gnu",
+	"x86_64-macos-none",
+	"aarch64-macos-none",
+	"x86_64-windows-gnu",
+}
+
+func init() {
+	builders.Register("zig", Builder{})
+}
+
+// Builder is the Zig implementation of build.Builder.
+type Builder struct{}
+
+// WithDefaults sets the default values for a Zig build.
+func (Builder) WithDefaults(build config.Build) (config.Build, error) {
+	if build.Main == "" {
+		build.Main = "main.zig"
+	}
+	return build, nil
+}
+
+// DefaultTargets returns the arch-os-abi triples to build for when a
+// build doesn't set `targets` explicitly.
+func (Builder) DefaultTargets(ctx *context.Context, build config.Build) ([]string, error) {
+	return defaultTargets, nil
+}
+
+// Parse breaks a "arch-os-abi" Zig target triple down into its parts.
+func (Builder) Parse(target string, flags config.FlagArray) (builders.Target, error) {
+	parts := strings.Split(target, "-")
+	if len(parts) != 3 {
+		return builders.Target{}, fmt.Errorf("invalid zig target triple: %s", target)
+	}
+
+	t := builders.Target{
+		Arch: parts[0],
+		Os:   parts[1],
+		Extra: map[string]string{
+			"abi":    parts[2],
+			"triple": target,
+		},
+	}
+	if t.Os == "windows" {
+		t.Ext = ".exe"
+	}
+	return t, nil
+}
+
+// Build builds a single binary via `zig build-exe -target <triple>`.
+func (b Builder) Build(ctx *context.Context, build config.Build, options builders.Options) error {
+	target, err := b.Parse(options.Target, build.Flags)
+	if err != nil {
+		return err
+	}
+
+	env := append(ctx.Env.Strings(), build.Env...)
+
+	args := []string{"build-exe", build.Main, "-target", target.Extra["triple"], "-femit-bin=" + options.Path}
+	args = append(args, build.Flags...)
+
+	/* #nosec */
+	cmd := exec.CommandContext(ctx, "zig", args...)
+	cmd.Env = env
+	cmd.Dir = build.Dir
+	entry := log.WithField("cmd", args).WithField("env", env)
+	cmd.Stdout = logext.NewWriter(entry)
+	cmd.Stderr = logext.NewErrWriter(entry)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to build for %s: %w", options.Target, err)
+	}
+	return nil
+}
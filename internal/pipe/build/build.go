@@ -10,6 +10,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/apex/log"
 	"github.com/goreleaser/goreleaser/internal/ids"
@@ -17,12 +18,16 @@ import (
 	"github.com/goreleaser/goreleaser/internal/semerrgroup"
 	"github.com/goreleaser/goreleaser/internal/tmpl"
 	builders "github.com/goreleaser/goreleaser/pkg/build"
+	"github.com/goreleaser/goreleaser/pkg/build/cache"
 	"github.com/goreleaser/goreleaser/pkg/config"
 	"github.com/goreleaser/goreleaser/pkg/context"
 	"github.com/mattn/go-shellwords"
 
 	// langs to init.
 	_ "github.com/goreleaser/goreleaser/internal/builders/golang"
+	_ "github.com/goreleaser/goreleaser/internal/builders/raw"
+	_ "github.com/goreleaser/goreleaser/internal/builders/rust"
+	_ "github.com/goreleaser/goreleaser/internal/builders/zig"
 )
 
 // Pipe for build.
@@ -81,7 +86,24 @@ func buildWithDefaults(ctx *context.Context, build config.Build) (config.Build,
 	for k, v := range build.Env {
 		build.Env[k] = os.ExpandEnv(v)
 	}
-	return builders.For(build.Lang).WithDefaults(build)
+	if build.Reproducible {
+		build.Env = append(build.Env, fmt.Sprintf("SOURCE_DATE_EPOCH=%d", ctx.Git.CommitDate.Unix()))
+		if build.Lang == "go" {
+			build.Flags = append(build.Flags, "-trimpath", "-buildvcs=false")
+		}
+	}
+	build, err := builders.For(build.Lang).WithDefaults(build)
+	if err != nil {
+		return build, err
+	}
+	if len(build.Targets) == 0 {
+		targets, err := builders.For(build.Lang).DefaultTargets(ctx, build)
+		if err != nil {
+			return build, fmt.Errorf("failed to get default targets: %w", err)
+		}
+		build.Targets = targets
+	}
+	return build, nil
 }
 
 func runPipeOnBuild(ctx *context.Context, build config.Build) error {
@@ -90,6 +112,9 @@ func runPipeOnBuild(ctx *context.Context, build config.Build) error {
 		return err
 	}
 
+	var mu sync.Mutex
+	var built []builders.Options
+
 	g := semerrgroup.New(ctx.Parallelism)
 	for _, target := range build.Targets {
 		target := target
@@ -100,22 +125,41 @@ func runPipeOnBuild(ctx *context.Context, build config.Build) error {
 				return err
 			}
 
-			if err := runHook(ctx, *opts, build.Env, build.Hooks.Pre); err != nil {
+			if err := runHook(ctx, build, *opts, build.Hooks.Pre); err != nil {
 				return fmt.Errorf("pre hook failed: %w", err)
 			}
 			if err := doBuild(ctx, build, *opts); err != nil {
 				return err
 			}
+			if build.Reproducible {
+				if err := verifyReproducible(ctx, build, *opts); err != nil {
+					return err
+				}
+			}
 			if !ctx.SkipPostBuildHooks {
-				if err := runHook(ctx, *opts, build.Env, build.Hooks.Post); err != nil {
+				if err := runHook(ctx, build, *opts, build.Hooks.Post); err != nil {
 					return fmt.Errorf("post hook failed: %w", err)
 				}
 			}
+
+			mu.Lock()
+			built = append(built, *opts)
+			mu.Unlock()
 			return nil
 		})
 	}
 
-	return g.Wait()
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	if build.Provenance.Enabled {
+		if err := writeProvenance(ctx, build, built); err != nil {
+			return fmt.Errorf("failed to write provenance: %w", err)
+		}
+	}
+
+	return nil
 }
 
 func proxy(ctx *context.Context, build config.Build) (config.Build, error) {
@@ -205,7 +249,7 @@ import _ "{{ .Proxy }}"
 	return build, nil
 }
 
-func runHook(ctx *context.Context, opts builders.Options, buildEnv []string, hooks config.BuildHooks) error {
+func runHook(ctx *context.Context, build config.Build, opts builders.Options, hooks config.BuildHooks) error {
 	if len(hooks) == 0 {
 		return nil
 	}
@@ -214,7 +258,7 @@ func runHook(ctx *context.Context, opts builders.Options, buildEnv []string, hoo
 		var env []string
 
 		env = append(env, ctx.Env.Strings()...)
-		env = append(env, buildEnv...)
+		env = append(env, build.Env...)
 
 		for _, rawEnv := range hook.Env {
 			e, err := tmpl.New(ctx).WithBuildOptions(opts).Apply(rawEnv)
@@ -242,6 +286,17 @@ func runHook(ctx *context.Context, opts builders.Options, buildEnv []string, hoo
 			return err
 		}
 
+		if build.Container.Image != "" {
+			workdir, err := resolveContainerWorkdir(build, dir)
+			if err != nil {
+				return fmt.Errorf("failed to resolve hook workdir: %w", err)
+			}
+			if err := runContainerized(ctx, build, opts, cmd, env, workdir); err != nil {
+				return err
+			}
+			continue
+		}
+
 		if err := run(ctx, dir, cmd, env); err != nil {
 			return err
 		}
@@ -251,24 +306,73 @@ func runHook(ctx *context.Context, opts builders.Options, buildEnv []string, hoo
 }
 
 func doBuild(ctx *context.Context, build config.Build, opts builders.Options) error {
-	return builders.For(build.Lang).Build(ctx, build, opts)
+	if !ctx.NoBuildCache {
+		key, err := buildCacheKey(ctx, build, opts)
+		if err != nil {
+			return fmt.Errorf("failed to compute build cache key: %w", err)
+		}
+		if cached, ok, err := cache.Lookup(key); err == nil && ok {
+			log.WithField("key", key).Info("build cache hit, skipping compile")
+			return cache.Restore(cached, opts.Path)
+		} else if err != nil {
+			log.WithError(err).Warn("failed to look up build cache, building anyway")
+		}
+		if err := runBuild(ctx, build, opts); err != nil {
+			return err
+		}
+		if err := cache.Put(key, opts.Path); err != nil {
+			log.WithError(err).Warn("failed to populate build cache")
+		}
+		return nil
+	}
+	return runBuild(ctx, build, opts)
 }
 
-func buildOptionsForTarget(ctx *context.Context, build config.Build, target string) (*builders.Options, error) {
-	ext := extFor(target, build.Flags)
-	var goos string
-	var goarch string
+func runBuild(ctx *context.Context, build config.Build, opts builders.Options) error {
+	if build.Container.Image == "" {
+		return builders.For(build.Lang).Build(ctx, build, opts)
+	}
+
+	cb, ok := builders.For(build.Lang).(builders.ContainerBuilder)
+	if !ok {
+		return fmt.Errorf("build.container is set but the %q builder doesn't support containerized builds", build.Lang)
+	}
+
+	ldflags, err := tmpl.New(ctx).WithBuildOptions(opts).Apply(strings.Join(build.Ldflags, " "))
+	if err != nil {
+		return fmt.Errorf("failed to apply ldflags template: %w", err)
+	}
+	build.Ldflags = config.FlagArray{ldflags}
+
+	containerOpts := opts
+	containerOpts.Path, err = mapToContainer(build, opts.Path)
+	if err != nil {
+		return fmt.Errorf("failed to map build output path into the container: %w", err)
+	}
 
-	if strings.Contains(target, "_") {
-		goos = strings.Split(target, "_")[0]
-		goarch = strings.Split(target, "_")[1]
+	workdir, err := resolveContainerWorkdir(build, "")
+	if err != nil {
+		return fmt.Errorf("failed to resolve build workdir: %w", err)
+	}
+
+	args, env, err := cb.Command(build, containerOpts)
+	if err != nil {
+		return err
+	}
+	return runContainerized(ctx, build, opts, args, env, workdir)
+}
+
+func buildOptionsForTarget(ctx *context.Context, build config.Build, target string) (*builders.Options, error) {
+	parsed, err := builders.For(build.Lang).Parse(target, build.Flags)
+	if err != nil {
+		return nil, err
 	}
 
 	buildOpts := builders.Options{
 		Target: target,
-		Ext:    ext,
-		Os:     goos,
-		Arch:   goarch,
+		Ext:    parsed.Ext,
+		Os:     parsed.Os,
+		Arch:   parsed.Arch,
 	}
 
 	binary, err := tmpl.New(ctx).WithBuildOptions(buildOpts).Apply(build.Binary)
@@ -277,7 +381,7 @@ func buildOptionsForTarget(ctx *context.Context, build config.Build, target stri
 	}
 
 	build.Binary = binary
-	name := build.Binary + ext
+	name := build.Binary + parsed.Ext
 	path, err := filepath.Abs(
 		filepath.Join(
 			ctx.Config.Dist,
@@ -295,24 +399,6 @@ func buildOptionsForTarget(ctx *context.Context, build config.Build, target stri
 	return &buildOpts, nil
 }
 
-func extFor(target string, flags config.FlagArray) string {
-	if strings.Contains(target, "windows") {
-		for _, s := range flags {
-			if s == "-buildmode=c-shared" {
-				return ".dll"
-			}
-			if s == "-buildmode=c-archive" {
-				return ".lib"
-			}
-		}
-		return ".exe"
-	}
-	if target == "js_wasm" {
-		return ".wasm"
-	}
-	return ""
-}
-
 func run(ctx *context.Context, dir string, command, env []string) error {
 	/* #nosec */
 	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
@@ -331,3 +417,78 @@ func run(ctx *context.Context, dir string, command, env []string) error {
 	}
 	return nil
 }
+
+func buildCacheKey(ctx *context.Context, build config.Build, opts builders.Options) (string, error) {
+	tpl := tmpl.New(ctx).WithBuildOptions(opts)
+	ldflags, err := tpl.Apply(strings.Join(build.Ldflags, " "))
+	if err != nil {
+		return "", err
+	}
+	flags, err := tpl.Apply(strings.Join(build.Flags, " "))
+	if err != nil {
+		return "", err
+	}
+
+	toolchain, err := toolchainVersion(build)
+	if err != nil {
+		return "", err
+	}
+
+	dir := build.Dir
+	if dir == "" {
+		dir = "."
+	}
+	sourceHash, err := cache.SourceHash(dir)
+	if err != nil {
+		return "", err
+	}
+
+	return cache.Key{
+		Lang:              build.Lang,
+		ID:                build.ID,
+		Binary:            build.Binary,
+		Main:              build.Main,
+		Os:                opts.Os,
+		Arch:              opts.Arch,
+		Ext:               opts.Ext,
+		Env:               append(ctx.Env.Strings(), build.Env...),
+		Ldflags:           ldflags,
+		Flags:             strings.Fields(flags),
+		ToolchainVersion:  toolchain,
+		ContainerImage:    build.Container.Image,
+		ContainerPlatform: build.Container.Platform,
+		SourceHash:        sourceHash,
+	}.Hash(), nil
+}
+
+// toolchainVersion fingerprints the toolchain that will actually build
+// build, so an upgrade of it invalidates the build cache. Unlike
+// build.Lang alone, this also catches a Go/Rust/Zig point release.
+func toolchainVersion(build config.Build) (string, error) {
+	var name string
+	switch build.Lang {
+	case "go", "":
+		name = build.GoBinary
+		if name == "" {
+			name = "go"
+		}
+		return runToolchainVersionCmd(name, "version")
+	case "rust":
+		return runToolchainVersionCmd("cargo", "--version")
+	case "zig":
+		return runToolchainVersionCmd("zig", "version")
+	case "raw":
+		// raw builds don't invoke a toolchain: nothing to fingerprint.
+		return "", nil
+	default:
+		return "", fmt.Errorf("don't know how to fingerprint the %q toolchain", build.Lang)
+	}
+}
+
+func runToolchainVersionCmd(binary string, args ...string) (string, error) {
+	out, err := exec.Command(binary, args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to get toolchain version: %w: %s", err, string(out))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
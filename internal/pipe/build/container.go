@@ -0,0 +1,92 @@
+package build
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	builders "github.com/goreleaser/goreleaser/pkg/build"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+)
+
+const defaultContainerEngine = "docker"
+
+// runContainerized runs command (and its env) inside build.Container.Image
+// via the `docker` CLI (the engine binary is always `docker`; podman is not
+// invoked directly), bind-mounting the project per
+// build.Container.InputMapping and translating the target's OS/Arch into
+// the container's --platform. workdir, if set, must already be a
+// container-side path (see mapToContainer) and is passed as `-w`.
+func runContainerized(ctx *context.Context, build config.Build, opts builders.Options, command, env []string, workdir string) error {
+	engine := defaultContainerEngine
+
+	args := []string{"run", "--rm"}
+	for src, dst := range build.Container.InputMapping {
+		args = append(args, "-v", fmt.Sprintf("%s:%s", src, dst))
+	}
+
+	if workdir != "" {
+		args = append(args, "-w", workdir)
+	}
+
+	platform := build.Container.Platform
+	if platform == "" && opts.Os != "" && opts.Arch != "" {
+		platform = fmt.Sprintf("%s/%s", opts.Os, opts.Arch)
+	}
+	if platform != "" {
+		args = append(args, "--platform", platform)
+	}
+
+	for _, e := range append(append([]string{}, build.Container.Env...), env...) {
+		args = append(args, "-e", e)
+	}
+
+	args = append(args, build.Container.Image)
+	args = append(args, command...)
+
+	return run(ctx, "", append([]string{engine}, args...), append(ctx.Env.Strings(), env...))
+}
+
+// resolveContainerWorkdir maps dir (falling back to build.Dir, then ".")
+// through build.Container.InputMapping, for use as a container's `-w`.
+func resolveContainerWorkdir(build config.Build, dir string) (string, error) {
+	if dir == "" {
+		dir = build.Dir
+	}
+	if dir == "" {
+		dir = "."
+	}
+	return mapToContainer(build, dir)
+}
+
+// mapToContainer translates a host path into its container-mounted
+// location, using build.Container.InputMapping entries as host path
+// prefixes to strip and replace with their container counterpart.
+func mapToContainer(build config.Build, hostPath string) (string, error) {
+	absHost, err := filepath.Abs(hostPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", hostPath, err)
+	}
+
+	var bestHost, bestContainer string
+	for host, container := range build.Container.InputMapping {
+		absMapped, err := filepath.Abs(host)
+		if err != nil {
+			continue
+		}
+		if absHost != absMapped && !strings.HasPrefix(absHost, absMapped+string(filepath.Separator)) {
+			continue
+		}
+		if len(absMapped) > len(bestHost) {
+			bestHost, bestContainer = absMapped, container
+		}
+	}
+
+	if bestHost == "" {
+		return "", fmt.Errorf("path %s is not covered by any build.container.input_mapping entry", hostPath)
+	}
+
+	rel := strings.TrimPrefix(absHost, bestHost)
+	return filepath.ToSlash(filepath.Join(bestContainer, rel)), nil
+}
@@ -0,0 +1,170 @@
+package build
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/goreleaser/goreleaser/internal/version"
+	builders "github.com/goreleaser/goreleaser/pkg/build"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+)
+
+const provenancePredicateType = "https://slsa.dev/provenance/v1"
+
+// provenanceStatement is an in-toto v1 attestation statement whose
+// predicate is a SLSA v1.0 build provenance predicate.
+type provenanceStatement struct {
+	Type          string              `json:"_type"`
+	Subject       []provenanceSubject `json:"subject"`
+	PredicateType string              `json:"predicateType"`
+	Predicate     provenancePredicate `json:"predicate"`
+}
+
+type provenanceSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+type provenancePredicate struct {
+	Builder     provenanceBuilder    `json:"builder"`
+	BuildType   string               `json:"buildType"`
+	Invocation  provenanceInvocation `json:"invocation"`
+	BuildConfig config.Build         `json:"buildConfig"`
+	Materials   []provenanceMaterial `json:"materials"`
+}
+
+type provenanceBuilder struct {
+	ID string `json:"id"`
+}
+
+type provenanceInvocation struct {
+	ConfigDigest string   `json:"configDigest,omitempty"`
+	Flags        []string `json:"flags,omitempty"`
+	// EnvAllowList only records the *names* of forwarded env vars, never
+	// their values, so secrets never end up in the attestation.
+	EnvAllowList []string `json:"envAllowList,omitempty"`
+}
+
+type provenanceMaterial struct {
+	URI    string            `json:"uri"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+// writeProvenance writes dist/<build.id>.provenance.json, an in-toto SLSA
+// v1.0 provenance attestation covering every target built for build.
+func writeProvenance(ctx *context.Context, build config.Build, built []builders.Options) error {
+	subjects := make([]provenanceSubject, 0, len(built))
+	for _, opts := range built {
+		sum, err := sha256sum(opts.Path)
+		if err != nil {
+			return err
+		}
+		subjects = append(subjects, provenanceSubject{
+			Name:   opts.Name,
+			Digest: map[string]string{"sha256": sum},
+		})
+	}
+
+	configDigest, err := configFileDigest(ctx)
+	if err != nil {
+		return err
+	}
+
+	materials := []provenanceMaterial{
+		{URI: "git+" + ctx.Git.URL, Digest: map[string]string{"sha1": ctx.Git.Commit}},
+	}
+	if build.IsProxied() {
+		materials = append(materials, provenanceMaterial{
+			URI: fmt.Sprintf("%s@%s", build.Proxy.Path, build.Proxy.Version),
+		})
+	}
+
+	stmt := provenanceStatement{
+		Type:          "https://in-toto.io/Statement/v1",
+		Subject:       subjects,
+		PredicateType: provenancePredicateType,
+		Predicate: provenancePredicate{
+			Builder:   provenanceBuilder{ID: "goreleaser@" + version.Version},
+			BuildType: "https://goreleaser.com/provenance/build@v1",
+			Invocation: provenanceInvocation{
+				ConfigDigest: configDigest,
+				Flags:        os.Args[1:],
+				EnvAllowList: envAllowList(build.Env),
+			},
+			BuildConfig: redactedBuildConfig(build),
+			Materials:   materials,
+		},
+	}
+
+	data, err := json.MarshalIndent(stmt, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(ctx.Config.Dist, build.ID+".provenance.json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return err
+	}
+
+	if build.Provenance.Signer == "cosign" {
+		if err := signProvenance(path, build.Provenance.KeyRef); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// envAllowList returns just the names (never the values) of the env vars
+// a build forwards, so the attestation records what was available to the
+// build without leaking any of it.
+func envAllowList(env []string) []string {
+	names := make([]string, 0, len(env))
+	for _, e := range env {
+		for i := 0; i < len(e); i++ {
+			if e[i] == '=' {
+				names = append(names, e[:i])
+				break
+			}
+		}
+	}
+	return names
+}
+
+// redactedBuildConfig strips value-bearing fields from build before it's
+// embedded in the attestation's buildConfig: Env (and Container.Env)
+// entries are "KEY=VALUE" strings, and embedding them verbatim would leak
+// exactly what envAllowList's names-only list is meant to keep out.
+func redactedBuildConfig(build config.Build) config.Build {
+	build.Env = nil
+	build.Container.Env = nil
+	return build
+}
+
+func configFileDigest(ctx *context.Context) (string, error) {
+	path := ctx.Config.ConfigFile
+	if path == "" {
+		return "", nil
+	}
+	return sha256sum(path)
+}
+
+// signProvenance produces a detached <path>.sig via `cosign sign-blob`.
+func signProvenance(path, keyRef string) error {
+	args := []string{"sign-blob", "--yes", "--output-signature", path + ".sig"}
+	if keyRef != "" {
+		args = append(args, "--key", keyRef)
+	}
+	args = append(args, path)
+
+	/* #nosec */
+	out, err := exec.Command("cosign", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to sign provenance with cosign: %w: %s", err, string(out))
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package build
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/apex/log"
+	builders "github.com/goreleaser/goreleaser/pkg/build"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+)
+
+// verifyReproducible re-runs the build for opts.Target into a scratch
+// directory and fails if the two resulting binaries aren't byte-for-byte
+// identical. build is expected to already carry whatever
+// reproducibility-related env/flags it needs (SOURCE_DATE_EPOCH,
+// -buildvcs=false, ...) via buildWithDefaults, so it's rebuilt unchanged:
+// if the primary build didn't get the same treatment, they'll never match.
+func verifyReproducible(ctx *context.Context, build config.Build, opts builders.Options) error {
+	log := log.WithField("build", build.ID).WithField("target", opts.Target)
+	log.Info("verifying reproducibility")
+
+	scratch := opts
+	scratch.Path = filepath.Join(
+		ctx.Config.Dist,
+		fmt.Sprintf("%s_%s_repro", build.ID, opts.Target),
+		opts.Name,
+	)
+	if err := os.MkdirAll(filepath.Dir(scratch.Path), 0o755); err != nil {
+		return fmt.Errorf("failed to prepare reproducible build dir: %w", err)
+	}
+
+	if err := builders.For(build.Lang).Build(ctx, build, scratch); err != nil {
+		return fmt.Errorf("reproducible build failed: %w", err)
+	}
+
+	sumA, err := sha256sum(opts.Path)
+	if err != nil {
+		return err
+	}
+	sumB, err := sha256sum(scratch.Path)
+	if err != nil {
+		return err
+	}
+	if sumA == sumB {
+		log.Info("build is reproducible")
+		return nil
+	}
+
+	diff, diffErr := diffBinaries(opts.Path, scratch.Path)
+	if diffErr != nil {
+		diff = diffErr.Error()
+	}
+	return fmt.Errorf("build for %s is not reproducible: sha256 mismatch (%s != %s)\n%s", opts.Target, sumA, sumB, diff)
+}
+
+func sha256sum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s for hashing: %w", path, err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("failed to hash %s: %w", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// diffBinaries reports what differs between the two binaries, preferring
+// diffoscope when it's available on PATH and falling back to a simple
+// byte-range report otherwise.
+func diffBinaries(a, b string) (string, error) {
+	if _, err := exec.LookPath("diffoscope"); err == nil {
+		/* #nosec */
+		out, err := exec.Command("diffoscope", "--text", "-", a, b).CombinedOutput()
+		if err != nil && len(out) == 0 {
+			return "", fmt.Errorf("diffoscope failed: %w", err)
+		}
+		return string(out), nil
+	}
+	return diffByteRanges(a, b)
+}
+
+func diffByteRanges(a, b string) (string, error) {
+	contentA, err := os.ReadFile(a)
+	if err != nil {
+		return "", err
+	}
+	contentB, err := os.ReadFile(b)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s is %d bytes, %s is %d bytes\n", a, len(contentA), b, len(contentB))
+
+	const chunk = 4096
+	n := len(contentA)
+	if len(contentB) < n {
+		n = len(contentB)
+	}
+	for i := 0; i < n; i += chunk {
+		end := i + chunk
+		if end > n {
+			end = n
+		}
+		if !bytes.Equal(contentA[i:end], contentB[i:end]) {
+			fmt.Fprintf(&buf, "bytes [%d, %d) differ\n", i, end)
+		}
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,8 @@
+// Package version holds goreleaser's own version, set via -ldflags at
+// release time.
+package version
+
+// Version is goreleaser's own version. Overridden via
+// `-X github.com/goreleaser/goreleaser/internal/version.Version=...` when
+// goreleaser releases itself.
+var Version = "dev"
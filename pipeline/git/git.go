@@ -0,0 +1,140 @@
+// Package git provides a pipe that determine the current git info
+// (tag, commit, diff) used to version and annotate the release.
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/goreleaser/goreleaser/context"
+)
+
+// Pipe for git.
+type Pipe struct{}
+
+// Description of the pipe.
+func (Pipe) Description() string {
+	return "getting and validating git state"
+}
+
+// Run the pipe.
+func (Pipe) Run(ctx *context.Context) error {
+	if _, err := git("status"); err != nil {
+		return fmt.Errorf("current folder is not a git repository: %w", err)
+	}
+
+	if err := ensureUnshallow(ctx); err != nil {
+		return err
+	}
+
+	info, err := getGitInfo(ctx)
+	if err != nil {
+		return err
+	}
+	ctx.Git = info
+
+	if err := validateVersion(ctx.Git.CurrentTag); err != nil {
+		return err
+	}
+
+	if ctx.Config.Git.RequireSignedTag {
+		// ctx.Git.CurrentTag has already had TagPrefix stripped off for
+		// display/versioning, but `git verify-tag` needs the real tag name.
+		rawTag := ctx.Config.Git.TagPrefix + ctx.Git.CurrentTag
+		if err := verifySignedTag(rawTag, ctx.Config.Git.AllowedSignersFile); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func ensureUnshallow(ctx *context.Context) error {
+	out, err := git("rev-parse", "--is-shallow-repository")
+	if err != nil {
+		return fmt.Errorf("failed to check whether this is a shallow clone: %w", err)
+	}
+	if strings.TrimSpace(out) != "true" {
+		return nil
+	}
+
+	if ctx.Strict {
+		return fmt.Errorf("this is a shallow clone, tags and history may be incomplete: run with a full clone, or `git fetch --unshallow --tags`")
+	}
+
+	if _, err := git("fetch", "--unshallow", "--tags"); err != nil {
+		return fmt.Errorf("this is a shallow clone, fetching full history failed: %w", err)
+	}
+	return nil
+}
+
+func getGitInfo(ctx *context.Context) (context.GitInfo, error) {
+	commit, err := git("show", "--format=%H", "HEAD", "--quiet")
+	if err != nil {
+		return context.GitInfo{}, fmt.Errorf("couldn't get current commit: %w", err)
+	}
+
+	args := []string{"describe", "--tags"}
+	if prefix := ctx.Config.Git.TagPrefix; prefix != "" {
+		args = append(args, "--match", prefix+"v*")
+	}
+
+	current, err := git(args...)
+	if err != nil {
+		return context.GitInfo{}, fmt.Errorf("couldn't get current tag: %w", err)
+	}
+	current = strings.TrimSpace(strings.Split(current, "\n")[0])
+	current = strings.TrimPrefix(current, ctx.Config.Git.TagPrefix)
+
+	previous, err := previousTag(current, ctx.Config.Git.TagPrefix)
+	if err != nil {
+		previous = ""
+	}
+
+	diff, _ := git("diff", previous, current)
+
+	return context.GitInfo{
+		CurrentTag:  current,
+		PreviousTag: previous,
+		Commit:      strings.Split(commit, "\n")[0],
+		Diff:        diff,
+	}, nil
+}
+
+func previousTag(current, tagPrefix string) (string, error) {
+	args := []string{"describe", "--tags", "--abbrev=0"}
+	if current != "" {
+		args = append(args, fmt.Sprintf("%s%s^", tagPrefix, current))
+	}
+	out, err := git(args...)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(strings.TrimSpace(out), tagPrefix), nil
+}
+
+func validateVersion(tag string) error {
+	if _, err := semver.NewVersion(tag); err != nil {
+		return fmt.Errorf("%s is not in a valid version format", tag)
+	}
+	return nil
+}
+
+func verifySignedTag(tag, allowedSignersFile string) error {
+	args := []string{"verify-tag", tag}
+	if allowedSignersFile != "" {
+		args = append([]string{"-c", "gpg.ssh.allowedSignersFile=" + allowedSignersFile}, args...)
+	}
+	if out, err := git(args...); err != nil {
+		return fmt.Errorf("tag %s is not signed, or its signature could not be verified: %w: %s", tag, err, out)
+	}
+	return nil
+}
+
+func git(args ...string) (string, error) {
+	/* #nosec */
+	out, err := exec.Command("git", args...).CombinedOutput()
+	return string(out), err
+}
@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"testing"
 
 	"github.com/goreleaser/goreleaser/config"
@@ -83,6 +84,106 @@ func TestInvalidTagFormat(t *testing.T) {
 	assert.Equal("sadasd", ctx.Git.CurrentTag)
 }
 
+func TestMonorepoTagPrefix(t *testing.T) {
+	var assert = assert.New(t)
+	_, back := createAndChdir(t)
+	defer back()
+	assert.NoError(exec.Command("git", "init").Run())
+	assert.NoError(exec.Command("git", "commit", "--allow-empty", "-m", "commit1").Run())
+	assert.NoError(exec.Command("git", "tag", "subpkg/v1.0.0").Run())
+	var ctx = &context.Context{
+		Config: config.Project{
+			Git: config.Git{
+				TagPrefix: "subpkg/",
+			},
+		},
+	}
+	assert.NoError(Pipe{}.Run(ctx))
+	assert.Equal("v1.0.0", ctx.Git.CurrentTag)
+}
+
+func TestMonorepoTagPrefixSignedTag(t *testing.T) {
+	var assert = assert.New(t)
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not available")
+	}
+
+	dir, back := createAndChdir(t)
+	defer back()
+
+	keyPath := filepath.Join(dir, "id_ed25519")
+	assert.NoError(exec.Command("ssh-keygen", "-t", "ed25519", "-N", "", "-f", keyPath).Run())
+	pub, err := ioutil.ReadFile(keyPath + ".pub")
+	assert.NoError(err)
+
+	allowedSigners := filepath.Join(dir, "allowed_signers")
+	assert.NoError(ioutil.WriteFile(allowedSigners, append([]byte("committer "), pub...), 0o644))
+
+	assert.NoError(exec.Command("git", "init").Run())
+	assert.NoError(exec.Command("git", "config", "user.email", "committer@example.com").Run())
+	assert.NoError(exec.Command("git", "config", "user.name", "committer").Run())
+	assert.NoError(exec.Command("git", "config", "gpg.format", "ssh").Run())
+	assert.NoError(exec.Command("git", "config", "user.signingkey", keyPath).Run())
+	assert.NoError(exec.Command("git", "commit", "--allow-empty", "-m", "commit1").Run())
+	assert.NoError(exec.Command("git", "tag", "-s", "subpkg/v1.0.0", "-m", "release").Run())
+
+	var ctx = &context.Context{
+		Config: config.Project{
+			Git: config.Git{
+				TagPrefix:          "subpkg/",
+				RequireSignedTag:   true,
+				AllowedSignersFile: allowedSigners,
+			},
+		},
+	}
+	assert.NoError(Pipe{}.Run(ctx))
+	assert.Equal("v1.0.0", ctx.Git.CurrentTag)
+}
+
+func TestShallowRepository(t *testing.T) {
+	var assert = assert.New(t)
+	origin, err := ioutil.TempDir("", "goreleasertest")
+	assert.NoError(err)
+	assert.NoError(runIn(origin, "git", "init"))
+	assert.NoError(runIn(origin, "git", "commit", "--allow-empty", "-m", "commit1"))
+	assert.NoError(runIn(origin, "git", "tag", "v0.0.1"))
+
+	shallow, back := createAndChdir(t)
+	defer back()
+	assert.NoError(exec.Command("git", "clone", "--depth", "1", "file://"+origin, shallow).Run())
+
+	var ctx = &context.Context{
+		Config: config.Project{},
+	}
+	assert.NoError(Pipe{}.Run(ctx))
+	assert.Equal("v0.0.1", ctx.Git.CurrentTag)
+}
+
+func TestShallowRepositoryStrict(t *testing.T) {
+	var assert = assert.New(t)
+	origin, err := ioutil.TempDir("", "goreleasertest")
+	assert.NoError(err)
+	assert.NoError(runIn(origin, "git", "init"))
+	assert.NoError(runIn(origin, "git", "commit", "--allow-empty", "-m", "commit1"))
+	assert.NoError(runIn(origin, "git", "tag", "v0.0.1"))
+
+	shallow, back := createAndChdir(t)
+	defer back()
+	assert.NoError(exec.Command("git", "clone", "--depth", "1", "file://"+origin, shallow).Run())
+
+	var ctx = &context.Context{
+		Config: config.Project{},
+		Strict: true,
+	}
+	assert.Error(Pipe{}.Run(ctx))
+}
+
+func runIn(dir string, args ...string) error {
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Dir = dir
+	return cmd.Run()
+}
+
 func createAndChdir(t *testing.T) (current string, back func()) {
 	var assert = assert.New(t)
 	folder, err := ioutil.TempDir("", "goreleasertest")
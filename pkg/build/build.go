@@ -0,0 +1,124 @@
+// Package build provides an interface and registry that external and
+// in-tree packages use to implement language-specific builders.
+//
+// A builder is registered from an init func, the same way Go's standard
+// library registers database/sql drivers:
+//
+//	import _ "github.com/goreleaser/goreleaser/internal/builders/golang"
+//
+// Importing a builder package for its side effects is enough to make its
+// `lang` available in `build.lang` in the goreleaser config. This also
+// means a third-party module can ship its own builder and register it
+// without ever touching this repository.
+package build
+
+import (
+	"fmt"
+
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+)
+
+var builders = map[string]Builder{}
+
+// Register a new builder for the given lang.
+func Register(lang string, builder Builder) {
+	builders[lang] = builder
+}
+
+// For gets the builder registered for the given lang.
+//
+// If lang hasn't been registered, it returns a builder that fails with a
+// descriptive error instead of a nil pointer, so the missing-builder case
+// surfaces the same way any other config mistake would.
+func For(lang string) Builder {
+	b, ok := builders[lang]
+	if !ok {
+		return unknownBuilder{lang: lang}
+	}
+	return b
+}
+
+// Target is a single build target parsed out of a build's raw target
+// string (e.g. "linux_amd64" for Go, or "x86_64-unknown-linux-gnu" for
+// Rust), in whatever vocabulary the builder's ecosystem uses.
+type Target struct {
+	// Os is the target operating system.
+	Os string
+	// Arch is the target architecture.
+	Arch string
+	// Extra carries any builder-specific dimensions that don't fit Os/Arch,
+	// such as Go's GOARM/GOMIPS or a libc variant.
+	Extra map[string]string
+	// Ext is the file extension the produced binary should have for this
+	// target (e.g. ".exe" on windows).
+	Ext string
+}
+
+// Options are the options used to run a single build.
+type Options struct {
+	Name   string
+	Path   string
+	Target string
+	Ext    string
+	Os     string
+	Arch   string
+	Arm    string
+	Mips   string
+}
+
+// Builder defines a builder for a single language/ecosystem.
+//
+// Implementations are expected to be stateless and registered via Register
+// so that a build's `lang` is all a user needs to opt into a given
+// toolchain.
+type Builder interface {
+	// WithDefaults sets the default values for a build.
+	WithDefaults(build config.Build) (config.Build, error)
+
+	// Build builds a single binary for the given target.
+	Build(ctx *context.Context, build config.Build, options Options) error
+
+	// Parse breaks a raw target string down into the OS/arch/extras it
+	// encodes and the file extension the resulting binary should have,
+	// so callers don't need to know the builder's own target syntax.
+	Parse(target string, flags config.FlagArray) (Target, error)
+
+	// DefaultTargets returns the targets to build for when a build config
+	// doesn't set `targets` explicitly.
+	DefaultTargets(ctx *context.Context, build config.Build) ([]string, error)
+}
+
+// ContainerBuilder is implemented by builders that can express their
+// build as a plain command line, so the build pipe can run that command
+// line inside a container (see config.Build.Container) instead of
+// invoking Build, which is free to run the compiler in-process.
+type ContainerBuilder interface {
+	Builder
+
+	// Command returns the argv and extra environment variables needed to
+	// run this build on the command line, e.g. ["go", "build", ...].
+	Command(build config.Build, options Options) (args []string, env []string, err error)
+}
+
+type unknownBuilder struct{ lang string }
+
+func (u unknownBuilder) WithDefaults(build config.Build) (config.Build, error) {
+	return build, u.err()
+}
+
+func (u unknownBuilder) Build(ctx *context.Context, build config.Build, options Options) error {
+	return u.err()
+}
+
+func (u unknownBuilder) Parse(target string, flags config.FlagArray) (Target, error) {
+	return Target{}, u.err()
+}
+
+func (u unknownBuilder) DefaultTargets(ctx *context.Context, build config.Build) ([]string, error) {
+	return nil, u.err()
+}
+
+func (u unknownBuilder) err() error {
+	return fmt.Errorf("no builder registered for lang: %s", u.lang)
+}
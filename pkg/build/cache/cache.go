@@ -0,0 +1,300 @@
+// Package cache implements an on-disk cache for built binaries, keyed on
+// a fingerprint of everything that can affect the output of a build: its
+// resolved options, env, flags, toolchain version, and source tree.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Key describes everything that can change a build's output, used to
+// compute the cache key for it.
+type Key struct {
+	// Lang is the builder identity (e.g. "go", "rust", "zig"), so builds
+	// using different toolchains never collide even if their other
+	// fields happen to match.
+	Lang string
+	// ID, Binary, and Main identify which build config and entry point
+	// produced the output, so two builds that share a source tree and
+	// target but compile different binaries/packages never collide.
+	ID      string
+	Binary  string
+	Main    string
+	Os      string
+	Arch    string
+	Ext     string
+	Env     []string
+	Ldflags string
+	Flags   []string
+	// ToolchainVersion is, e.g., the output of `go version`.
+	ToolchainVersion string
+	// ContainerImage and ContainerPlatform, when set, mean the build ran
+	// inside that image/platform rather than on the host, so a
+	// containerized build never collides with (and restores) a host one
+	// built with an otherwise-identical key.
+	ContainerImage    string
+	ContainerPlatform string
+	// SourceHash is a Merkle hash of the source tree the build reads from.
+	SourceHash string
+}
+
+// Hash computes the cache key's hex-encoded sha256 digest.
+func (k Key) Hash() string {
+	env := append([]string{}, k.Env...)
+	flags := append([]string{}, k.Flags...)
+	sort.Strings(env)
+	sort.Strings(flags)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "lang=%s\n", k.Lang)
+	fmt.Fprintf(h, "id=%s\nbinary=%s\nmain=%s\n", k.ID, k.Binary, k.Main)
+	fmt.Fprintf(h, "os=%s\narch=%s\next=%s\n", k.Os, k.Arch, k.Ext)
+	fmt.Fprintf(h, "env=%v\n", env)
+	fmt.Fprintf(h, "ldflags=%s\n", k.Ldflags)
+	fmt.Fprintf(h, "flags=%v\n", flags)
+	fmt.Fprintf(h, "toolchain=%s\n", k.ToolchainVersion)
+	fmt.Fprintf(h, "container=%s/%s\n", k.ContainerImage, k.ContainerPlatform)
+	fmt.Fprintf(h, "source=%s\n", k.SourceHash)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// SourceHash returns a Merkle hash of the source tree rooted at dir: the
+// sha256 of the sorted list of "<path> <sha256 of contents>" lines for
+// every file under dir. It uses `git ls-files -z` to enumerate files when
+// dir is inside a git work tree (so .gitignore is respected for free),
+// falling back to a plain filesystem walk otherwise.
+func SourceHash(dir string) (string, error) {
+	files, err := gitFiles(dir)
+	if err != nil {
+		files, err = walkFiles(dir)
+		if err != nil {
+			return "", fmt.Errorf("failed to hash source tree: %w", err)
+		}
+	}
+	sort.Strings(files)
+
+	h := sha256.New()
+	for _, f := range files {
+		sum, err := fileSHA256(filepath.Join(dir, f))
+		if err != nil {
+			return "", fmt.Errorf("failed to hash %s: %w", f, err)
+		}
+		fmt.Fprintf(h, "%s %s\n", f, sum)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func gitFiles(dir string) ([]string, error) {
+	/* #nosec */
+	cmd := exec.Command("git", "ls-files", "-z", "--cached", "--others", "--exclude-standard")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, f := range splitNul(out) {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+func splitNul(b []byte) []string {
+	var result []string
+	start := 0
+	for i, c := range b {
+		if c == 0 {
+			result = append(result, string(b[start:i]))
+			start = i + 1
+		}
+	}
+	return result
+}
+
+func walkFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, rel)
+		return nil
+	})
+	return files, err
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Dir returns the root of the build cache, honoring $XDG_CACHE_HOME.
+func Dir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve cache dir: %w", err)
+	}
+	return filepath.Join(base, "goreleaser", "builds"), nil
+}
+
+// Lookup returns the cached binary path for key, and whether it exists.
+func Lookup(key string) (string, bool, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", false, err
+	}
+	path := filepath.Join(dir, key)
+	if _, err := os.Stat(path); err != nil {
+		return "", false, nil
+	}
+	return path, true, nil
+}
+
+// Put stores builtPath in the cache under key, hard-linking it in when
+// possible and falling back to a copy, writing atomically via a temp
+// file + rename so concurrent builds never observe a partial entry.
+func Put(key, builtPath string) error {
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create cache dir: %w", err)
+	}
+
+	dst := filepath.Join(dir, key)
+
+	tmpFile, err := os.CreateTemp(dir, key+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp cache file: %w", err)
+	}
+	tmp := tmpFile.Name()
+	tmpFile.Close()
+	_ = os.Remove(tmp)
+
+	if err := os.Link(builtPath, tmp); err != nil {
+		if err := copyFile(builtPath, tmp); err != nil {
+			return fmt.Errorf("failed to populate cache: %w", err)
+		}
+	}
+	return os.Rename(tmp, dst)
+}
+
+// Restore hard-links (or copies) the cached binary at path into dst.
+func Restore(path, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return fmt.Errorf("failed to create output dir: %w", err)
+	}
+	_ = os.Remove(dst)
+	if err := os.Link(path, dst); err != nil {
+		return copyFile(path, dst)
+	}
+	return nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, info.Mode())
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
+
+// Prune removes cache entries older than maxAge, and then, if the cache
+// is still larger than maxSize bytes, removes the oldest entries until it
+// fits, returning how many bytes were freed.
+func Prune(maxAge time.Duration, maxSize int64) (int64, error) {
+	dir, err := Dir()
+	if err != nil {
+		return 0, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to list build cache: %w", err)
+	}
+
+	type entry struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var all []entry
+	var total int64
+	now := time.Now()
+	var freed int64
+
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		if maxAge > 0 && now.Sub(info.ModTime()) > maxAge {
+			freed += info.Size()
+			_ = os.Remove(path)
+			continue
+		}
+		all = append(all, entry{path, info.Size(), info.ModTime()})
+		total += info.Size()
+	}
+
+	if maxSize <= 0 || total <= maxSize {
+		return freed, nil
+	}
+
+	sort.Slice(all, func(i, j int) bool { return all[i].modTime.Before(all[j].modTime) })
+	for _, e := range all {
+		if total <= maxSize {
+			break
+		}
+		if err := os.Remove(e.path); err != nil {
+			continue
+		}
+		total -= e.size
+		freed += e.size
+	}
+	return freed, nil
+}
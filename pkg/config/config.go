@@ -0,0 +1,95 @@
+// Package config contains the data types and defaults used by goreleaser
+// to parse and hold its configuration.
+package config
+
+// FlagArray is a wrapper for []string used by some property.
+type FlagArray []string
+
+// BuildHook is a hook that runs before or after a build.
+type BuildHook struct {
+	Dir string   `yaml:"dir,omitempty"`
+	Cmd string   `yaml:"cmd,omitempty"`
+	Env []string `yaml:"env,omitempty"`
+}
+
+// BuildHooks is a list of build hooks.
+type BuildHooks []BuildHook
+
+// ProxyConfig is the go mod proxy config.
+type ProxyConfig struct {
+	Path    string `yaml:"path,omitempty"`
+	Version string `yaml:"version,omitempty"`
+}
+
+// Container configures running a build's compile step and hooks inside a
+// container, started with the `docker` CLI, instead of on the host.
+type Container struct {
+	Image string `yaml:"image,omitempty"`
+	// InputMapping maps host paths to the paths they should be bind
+	// mounted at inside the container, e.g. {".": "/src", "dist": "/dist"}.
+	InputMapping map[string]string `yaml:"input_mapping,omitempty"`
+	Env          []string          `yaml:"env,omitempty"`
+	// Platform is passed to `docker run --platform`. When empty, it's
+	// derived from the target's GOOS/GOARCH.
+	Platform string `yaml:"platform,omitempty"`
+}
+
+// Build contains the configuration for a build.
+type Build struct {
+	ID       string            `yaml:"id,omitempty"`
+	Lang     string            `yaml:"lang,omitempty"`
+	Binary   string            `yaml:"binary,omitempty"`
+	Main     string            `yaml:"main,omitempty"`
+	Dir      string            `yaml:"dir,omitempty"`
+	GoBinary string            `yaml:"gobinary,omitempty"`
+	Skip     bool              `yaml:"skip,omitempty"`
+	Targets  []string          `yaml:"targets,omitempty"`
+	Env      []string          `yaml:"env,omitempty"`
+	Flags    FlagArray         `yaml:"flags,omitempty"`
+	Ldflags  FlagArray         `yaml:"ldflags,omitempty"`
+	Hooks    struct {
+		Pre  BuildHooks `yaml:"pre,omitempty"`
+		Post BuildHooks `yaml:"post,omitempty"`
+	} `yaml:"hooks,omitempty"`
+	Proxy ProxyConfig `yaml:"proxy,omitempty"`
+
+	// Container, when set, makes the build pipe run the compile step and
+	// its pre/post hooks inside the given image instead of on the host.
+	Container Container `yaml:"container,omitempty"`
+
+	// Reproducible, when set, makes the build pipe re-run each target's
+	// build in a scratch directory with SOURCE_DATE_EPOCH pinned to the
+	// commit date and byte-compare the two outputs, failing the pipe on
+	// any mismatch.
+	Reproducible bool `yaml:"reproducible,omitempty"`
+
+	// Provenance configures emitting a SLSA provenance attestation for
+	// this build's artifacts once all of its targets have built.
+	Provenance Provenance `yaml:"provenance,omitempty"`
+}
+
+// Provenance configures SLSA build provenance generation.
+type Provenance struct {
+	Enabled bool `yaml:"enabled,omitempty"`
+	// Signer is "cosign" or "none". Defaults to "none".
+	Signer string `yaml:"signer,omitempty"`
+	KeyRef string `yaml:"key_ref,omitempty"`
+}
+
+// IsProxied returns true if the build should be proxied through a go
+// module.
+func (b Build) IsProxied() bool {
+	return b.Proxy.Path != ""
+}
+
+// Project includes all project configuration.
+type Project struct {
+	ProjectName string  `yaml:"project_name,omitempty"`
+	Dist        string  `yaml:"dist,omitempty"`
+	Builds      []Build `yaml:"builds,omitempty"`
+	SingleBuild Build   `yaml:"build,omitempty"`
+
+	// ConfigFile is the path goreleaser loaded this config from, used to
+	// fingerprint the config that produced a build's provenance.
+	ConfigFile string `yaml:"-"`
+}
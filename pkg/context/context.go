@@ -0,0 +1,77 @@
+// Package context provides the context used throughout goreleaser to
+// pass around its configuration and the state collected by each pipe.
+package context
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/goreleaser/goreleaser/pkg/config"
+)
+
+// GitInfo includes tags and diffs used in some point.
+type GitInfo struct {
+	CurrentTag  string
+	PreviousTag string
+	Commit      string
+	ShortCommit string
+	FullCommit  string
+	CommitDate  time.Time
+	URL         string
+	Diff        string
+}
+
+// Env is the environment variables available to templates and hooks.
+type Env map[string]string
+
+// Strings returns the environment variables as a `k=v` slice, as consumed
+// by exec.Cmd.Env.
+func (e Env) Strings() []string {
+	var result []string
+	for k, v := range e {
+		result = append(result, k+"="+v)
+	}
+	return result
+}
+
+// Context carries the config and state along the pipe chain.
+type Context struct {
+	context.Context
+	Config             config.Project
+	Env                Env
+	Git                GitInfo
+	Parallelism        int
+	SkipPostBuildHooks bool
+	// NoBuildCache disables the on-disk build cache (pkg/build/cache),
+	// forcing every target to be recompiled even on a cache hit.
+	NoBuildCache bool
+}
+
+// New context.
+func New(config config.Project) *Context {
+	return Wrap(context.Background(), config)
+}
+
+// Wrap wraps an existing context.Context with the given config.
+func Wrap(ctx context.Context, config config.Project) *Context {
+	return &Context{
+		Context:     ctx,
+		Config:      config,
+		Env:         splitEnv(os.Environ()),
+		Parallelism: runtime.NumCPU(),
+	}
+}
+
+func splitEnv(env []string) Env {
+	result := Env{}
+	for _, e := range env {
+		parts := strings.SplitN(e, "=", 2)
+		if len(parts) == 2 {
+			result[parts[0]] = parts[1]
+		}
+	}
+	return result
+}